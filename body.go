@@ -0,0 +1,159 @@
+package valmid
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"reflect"
+	"strings"
+
+	"github.com/ggicci/httpin/core"
+)
+
+func init() {
+	// Replace httpin's built-in "body" directive so that in:"body=auto"
+	// can pick a codec at request time and in:"body=stream" can bind r.Body
+	// directly. Every other format name (json,
+	// xml, or anything a caller registered with core.RegisterBodyFormat)
+	// is delegated straight back to httpin's own implementation, so
+	// existing in:"body=json" fields keep working unchanged.
+	core.RegisterDirective("body", autoBodyDirective{}, true)
+}
+
+// UnsupportedMediaTypeError is returned when an in:"body=auto" field can't
+// be decoded because no registered Codec matches the request's
+// Content-Type. Middleware responds to it with HTTP 415 directly, since
+// there's no input to hand a per-route error handler.
+type UnsupportedMediaTypeError struct {
+	ContentType string
+}
+
+func (e *UnsupportedMediaTypeError) Error() string {
+	return fmt.Sprintf("valmid: no codec registered for Content-Type %q", e.ContentType)
+}
+
+// autoBodyDirective implements the "body" directive. For body=auto it
+// negotiates a Codec via Content-Type/Accept; for body=stream it binds the
+// field straight to r.Body, skipping any decode; for every other format it
+// defers to httpin's own core.DirectiveBody.
+//
+// body=multipart isn't handled here: httpin.Core.Decode always calls
+// r.ParseMultipartForm for a multipart/form-data request before running any
+// field directive, which would buffer the whole body before this directive
+// ever got a chance to hand back a streaming reader. Middleware detects
+// in:"body=multipart" fields itself and binds that route without going
+// through httpin at all; see multipart.go.
+type autoBodyDirective struct{}
+
+func (autoBodyDirective) Decode(rtm *core.DirectiveRuntime) error {
+	switch bodyFormat(rtm) {
+	case "auto":
+		return decodeAutoBody(rtm)
+	case "stream":
+		return decodeStreamBody(rtm)
+	default:
+		return (&core.DirectiveBody{}).Decode(rtm)
+	}
+}
+
+func (autoBodyDirective) Encode(rtm *core.DirectiveRuntime) error {
+	switch bodyFormat(rtm) {
+	case "auto":
+		return encodeAutoBody(rtm)
+	case "stream":
+		return fmt.Errorf("valmid: body=stream fields can't be encoded into an outgoing request")
+	default:
+		return (&core.DirectiveBody{}).Encode(rtm)
+	}
+}
+
+func decodeAutoBody(rtm *core.DirectiveRuntime) error {
+	req := rtm.GetRequest()
+	contentType := req.Header.Get("Content-Type")
+
+	var c Codec
+	var ok bool
+	if contentType == "" {
+		c, ok = defaultCodec()
+	} else {
+		c, ok = codecForContentType(contentType)
+	}
+	if !ok {
+		return &UnsupportedMediaTypeError{ContentType: contentType}
+	}
+	return c.Decode(req.Body, rtm.Value.Elem().Addr().Interface())
+}
+
+func encodeAutoBody(rtm *core.DirectiveRuntime) error {
+	c, _ := selectCodec(rtm.GetRequestBuilder().Header.Get("Accept"))
+	var buf bytes.Buffer
+	if err := c.Encode(&buf, rtm.Value.Interface()); err != nil {
+		return err
+	}
+	rtm.GetRequestBuilder().SetBody("auto", io.NopCloser(&buf))
+	rtm.MarkFieldSet(true)
+	return nil
+}
+
+// decodeStreamBody binds the in:"body=stream" field directly to r.Body
+// (or whatever it's assignable to, e.g. io.ReadCloser), skipping httpin's
+// buffered decode so a multi-GB upload never hits memory. The body is
+// guaranteed not to have been read before the handler runs; closing it is
+// the handler's responsibility.
+func decodeStreamBody(rtm *core.DirectiveRuntime) error {
+	return rtm.SetValue(rtm.GetRequest().Body)
+}
+
+// bodyFormat reads the body directive's format argument, e.g. the "auto" in
+// in:"body=auto", defaulting to "json" like httpin itself does.
+func bodyFormat(rtm *core.DirectiveRuntime) string {
+	if len(rtm.Directive.Argv) == 0 {
+		return "json"
+	}
+	return strings.ToLower(rtm.Directive.Argv[0])
+}
+
+// bodyTagFormat returns f's in:"body=..." format argument (e.g. "json",
+// "auto", "stream", "multipart") and whether f is a body field at all. A
+// field listing body as a fallback source, e.g. in:"query=x;body=json",
+// doesn't count; only a leading body= does.
+func bodyTagFormat(f reflect.StructField) (format string, ok bool) {
+	tag := f.Tag.Get("in")
+	if tag == "" {
+		return "", false
+	}
+	source, format, _ := strings.Cut(strings.SplitN(tag, ";", 2)[0], "=")
+	return format, source == "body"
+}
+
+// streamingBodyFields returns the names of t's fields bound with
+// in:"body=stream" or in:"body=multipart". Middleware excludes them from
+// validator.Struct, since traversing into a live *http.body or
+// *multipart.Reader would read from (or otherwise disturb) the body before
+// the handler gets a chance to stream it.
+func streamingBodyFields(t reflect.Type) []string {
+	if t == nil || t.Kind() != reflect.Struct {
+		return nil
+	}
+	var fields []string
+	for i := 0; i < t.NumField(); i++ {
+		if format, ok := bodyTagFormat(t.Field(i)); ok && (format == "stream" || format == "multipart") {
+			fields = append(fields, t.Field(i).Name)
+		}
+	}
+	return fields
+}
+
+// multipartBodyField returns the index of t's in:"body=multipart" field, if
+// it has one.
+func multipartBodyField(t reflect.Type) (index int, ok bool) {
+	if t == nil || t.Kind() != reflect.Struct {
+		return 0, false
+	}
+	for i := 0; i < t.NumField(); i++ {
+		if format, isBody := bodyTagFormat(t.Field(i)); isBody && format == "multipart" {
+			return i, true
+		}
+	}
+	return 0, false
+}
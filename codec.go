@@ -0,0 +1,101 @@
+package valmid
+
+import (
+	"io"
+	"mime"
+	"net/http"
+	"strings"
+	"sync"
+)
+
+// Codec decodes and encodes request/response bodies for a specific wire
+// format. Register custom codecs with RegisterBodyCodec to support
+// additional formats alongside the built-in JSON, XML, YAML, and protobuf
+// codecs.
+type Codec interface {
+	// Decode reads and decodes r into v.
+	Decode(r io.Reader, v any) error
+	// Encode encodes v and writes it to w.
+	Encode(w io.Writer, v any) error
+	// ContentType returns the MIME type this codec handles, e.g.
+	// "application/json".
+	ContentType() string
+}
+
+// defaultCodecName is used for an in:"body=auto" request with no
+// Content-Type header, and by WriteResponse when Accept is absent or matches
+// no registered codec's ContentType. An in:"body=auto" request whose
+// Content-Type matches no registered codec is rejected with 415 instead.
+const defaultCodecName = "json"
+
+var (
+	codecsMu sync.RWMutex
+	codecs   = map[string]Codec{}
+)
+
+// RegisterBodyCodec registers a Codec under name, making it available to
+// in:"body=auto" fields and WriteResponse. name is conventionally the wire
+// format's short name (e.g. "json", "yaml"); matching against a request's
+// Content-Type or Accept header is done via Codec.ContentType, not name.
+// Registering under an existing name replaces it.
+func RegisterBodyCodec(name string, c Codec) {
+	codecsMu.Lock()
+	defer codecsMu.Unlock()
+	codecs[name] = c
+}
+
+func init() {
+	RegisterBodyCodec("json", jsonCodec{})
+	RegisterBodyCodec("xml", xmlCodec{})
+	RegisterBodyCodec("yaml", yamlCodec{})
+	RegisterBodyCodec("protobuf", protobufCodec{})
+}
+
+// codecForContentType returns the registered codec whose ContentType matches
+// the media type in header, ignoring any parameters (e.g. "; charset=utf-8").
+func codecForContentType(header string) (Codec, bool) {
+	mediaType := header
+	if parsed, _, err := mime.ParseMediaType(header); err == nil {
+		mediaType = parsed
+	}
+	codecsMu.RLock()
+	defer codecsMu.RUnlock()
+	for _, c := range codecs {
+		if c.ContentType() == mediaType {
+			return c, true
+		}
+	}
+	return nil, false
+}
+
+func defaultCodec() (Codec, bool) {
+	codecsMu.RLock()
+	defer codecsMu.RUnlock()
+	c, ok := codecs[defaultCodecName]
+	return c, ok
+}
+
+// selectCodec picks the codec for header (a Content-Type or Accept value),
+// falling back to the default codec if header is empty or unmatched.
+func selectCodec(header string) (Codec, bool) {
+	for _, part := range strings.Split(header, ",") {
+		mediaType := strings.TrimSpace(strings.SplitN(part, ";", 2)[0])
+		if mediaType == "" || mediaType == "*/*" {
+			continue
+		}
+		if c, ok := codecForContentType(mediaType); ok {
+			return c, true
+		}
+	}
+	return defaultCodec()
+}
+
+// WriteResponse encodes v and writes it to w, choosing the codec via the
+// request's Accept header (falling back to JSON if Accept is absent or
+// matches no registered codec). It sets the Content-Type header to the
+// chosen codec's ContentType before writing.
+func WriteResponse[T any](w http.ResponseWriter, v T, r *http.Request) error {
+	c, _ := selectCodec(r.Header.Get("Accept"))
+	w.Header().Set("Content-Type", c.ContentType())
+	return c.Encode(w, v)
+}
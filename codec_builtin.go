@@ -0,0 +1,89 @@
+package valmid
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"reflect"
+
+	"google.golang.org/protobuf/proto"
+	"gopkg.in/yaml.v3"
+)
+
+// jsonCodec is the built-in application/json codec, registered under the
+// name "json".
+type jsonCodec struct{}
+
+func (jsonCodec) Decode(r io.Reader, v any) error { return json.NewDecoder(r).Decode(v) }
+func (jsonCodec) Encode(w io.Writer, v any) error { return json.NewEncoder(w).Encode(v) }
+func (jsonCodec) ContentType() string             { return "application/json" }
+
+// xmlCodec is the built-in application/xml codec, registered under the name
+// "xml".
+type xmlCodec struct{}
+
+func (xmlCodec) Decode(r io.Reader, v any) error { return xml.NewDecoder(r).Decode(v) }
+func (xmlCodec) Encode(w io.Writer, v any) error { return xml.NewEncoder(w).Encode(v) }
+func (xmlCodec) ContentType() string             { return "application/xml" }
+
+// yamlCodec is the built-in application/yaml codec, registered under the
+// name "yaml".
+type yamlCodec struct{}
+
+func (yamlCodec) Decode(r io.Reader, v any) error { return yaml.NewDecoder(r).Decode(v) }
+func (yamlCodec) Encode(w io.Writer, v any) error { return yaml.NewEncoder(w).Encode(v) }
+func (yamlCodec) ContentType() string             { return "application/yaml" }
+
+// protobufCodec is the built-in application/x-protobuf codec, registered
+// under the name "protobuf". It requires v to implement proto.Message.
+type protobufCodec struct{}
+
+func (protobufCodec) Decode(r io.Reader, v any) error {
+	msg, err := protoMessageFor(v)
+	if err != nil {
+		return err
+	}
+	b, err := io.ReadAll(r)
+	if err != nil {
+		return err
+	}
+	return proto.Unmarshal(b, msg)
+}
+
+func (protobufCodec) Encode(w io.Writer, v any) error {
+	msg, err := protoMessageFor(v)
+	if err != nil {
+		return err
+	}
+	b, err := proto.Marshal(msg)
+	if err != nil {
+		return err
+	}
+	_, err = w.Write(b)
+	return err
+}
+
+// protoMessageFor returns the proto.Message v refers to. v is ordinarily
+// already a proto.Message (e.g. a caller-supplied *T passed to
+// WriteResponse), but in:"body=auto" hands codecs a pointer to the field
+// itself, one level of indirection deeper than the field's own type; for a
+// field declared as a pointer to a message (the common case), that's a
+// **T. json, xml, and yaml all tolerate that extra indirection internally,
+// so unwrap it here too, allocating the message if the field is still nil.
+func protoMessageFor(v any) (proto.Message, error) {
+	if msg, ok := v.(proto.Message); ok {
+		return msg, nil
+	}
+	if rv := reflect.ValueOf(v); rv.Kind() == reflect.Ptr && rv.Elem().Kind() == reflect.Ptr {
+		if rv.Elem().IsNil() {
+			rv.Elem().Set(reflect.New(rv.Elem().Type().Elem()))
+		}
+		if msg, ok := rv.Elem().Interface().(proto.Message); ok {
+			return msg, nil
+		}
+	}
+	return nil, fmt.Errorf("valmid: protobuf codec requires a proto.Message, got %T", v)
+}
+
+func (protobufCodec) ContentType() string { return "application/x-protobuf" }
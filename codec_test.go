@@ -0,0 +1,140 @@
+package valmid_test
+
+import (
+	"bytes"
+	"encoding/xml"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/iamolegga/valmid"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+)
+
+type AutoBody struct {
+	Name string `json:"name" xml:"name" validate:"required,min=3"`
+}
+
+type AutoInput struct {
+	Body *AutoBody `in:"body=auto" validate:"required"`
+}
+
+func TestMiddleware_BodyAutoJSON(t *testing.T) {
+	handler := valmid.Middleware[AutoInput]()(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		input := valmid.Get[AutoInput](r)
+		if input.Body.Name != "John" {
+			t.Errorf("unexpected body: %+v", input.Body)
+		}
+	}))
+
+	req := httptest.NewRequest("POST", "/", strings.NewReader(`{"name":"John"}`))
+	req.Header.Set("Content-Type", "application/json")
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusOK {
+		t.Errorf("expected 200, got %d: %s", rec.Code, rec.Body.String())
+	}
+}
+
+func TestMiddleware_BodyAutoXML(t *testing.T) {
+	handler := valmid.Middleware[AutoInput]()(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		input := valmid.Get[AutoInput](r)
+		if input.Body.Name != "Jane" {
+			t.Errorf("unexpected body: %+v", input.Body)
+		}
+	}))
+
+	req := httptest.NewRequest("POST", "/", strings.NewReader(`<AutoBody><name>Jane</name></AutoBody>`))
+	req.Header.Set("Content-Type", "application/xml")
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusOK {
+		t.Errorf("expected 200, got %d: %s", rec.Code, rec.Body.String())
+	}
+}
+
+func TestMiddleware_BodyAutoYAML(t *testing.T) {
+	handler := valmid.Middleware[AutoInput]()(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		input := valmid.Get[AutoInput](r)
+		if input.Body.Name != "Yara" {
+			t.Errorf("unexpected body: %+v", input.Body)
+		}
+	}))
+
+	req := httptest.NewRequest("POST", "/", strings.NewReader("name: Yara\n"))
+	req.Header.Set("Content-Type", "application/yaml")
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusOK {
+		t.Errorf("expected 200, got %d: %s", rec.Code, rec.Body.String())
+	}
+}
+
+type ProtobufInput struct {
+	Body *wrapperspb.StringValue `in:"body=auto" validate:"required"`
+}
+
+func TestMiddleware_BodyAutoProtobuf(t *testing.T) {
+	handler := valmid.Middleware[ProtobufInput]()(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		input := valmid.Get[ProtobufInput](r)
+		if input.Body.Value != "Priya" {
+			t.Errorf("unexpected body: %+v", input.Body)
+		}
+	}))
+
+	raw, err := proto.Marshal(&wrapperspb.StringValue{Value: "Priya"})
+	if err != nil {
+		t.Fatalf("failed to marshal protobuf fixture: %v", err)
+	}
+
+	req := httptest.NewRequest("POST", "/", bytes.NewReader(raw))
+	req.Header.Set("Content-Type", "application/x-protobuf")
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusOK {
+		t.Errorf("expected 200, got %d: %s", rec.Code, rec.Body.String())
+	}
+}
+
+func TestMiddleware_BodyAutoUnsupportedMediaType(t *testing.T) {
+	handler := valmid.Middleware[AutoInput]()(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		t.Fatal("should not be called")
+	}))
+
+	req := httptest.NewRequest("POST", "/", strings.NewReader(`whatever`))
+	req.Header.Set("Content-Type", "application/x-does-not-exist")
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusUnsupportedMediaType {
+		t.Errorf("expected 415, got %d", rec.Code)
+	}
+}
+
+func TestWriteResponse(t *testing.T) {
+	rec := httptest.NewRecorder()
+	req := httptest.NewRequest("GET", "/", nil)
+	req.Header.Set("Accept", "application/xml")
+
+	if err := valmid.WriteResponse(rec, AutoBody{Name: "John"}, req); err != nil {
+		t.Fatalf("WriteResponse returned error: %v", err)
+	}
+
+	if ct := rec.Header().Get("Content-Type"); ct != "application/xml" {
+		t.Errorf("expected Content-Type application/xml, got %q", ct)
+	}
+
+	var got AutoBody
+	if err := xml.Unmarshal(rec.Body.Bytes(), &got); err != nil {
+		t.Fatalf("failed to unmarshal response: %v", err)
+	}
+	if got.Name != "John" {
+		t.Errorf("unexpected response body: %+v", got)
+	}
+}
@@ -34,6 +34,7 @@
 //	in:"header=Authorization" // HTTP header
 //	in:"form=field"           // Form field (application/x-www-form-urlencoded)
 //	in:"body=json"            // JSON body (binds to nested struct)
+//	in:"body=auto"            // Body decoded by Content-Type via a registered Codec
 //
 // Multiple sources and defaults:
 //
@@ -65,10 +66,62 @@
 //
 // Nested structs are validated automatically.
 //
+// # Content Negotiation
+//
+// in:"body=auto" decodes the body with whichever registered [Codec] matches
+// the request's Content-Type, instead of assuming JSON:
+//
+//	type Input struct {
+//	    Body *Body `in:"body=auto"`
+//	}
+//
+// JSON, XML, YAML, and protobuf codecs are registered out of the box.
+// Register additional formats with RegisterBodyCodec:
+//
+//	valmid.RegisterBodyCodec("toml", myTOMLCodec{})
+//
+// Write a matching response with the codec selected by the request's Accept
+// header:
+//
+//	valmid.WriteResponse(w, responseBody, r)
+//
+// # Streaming Uploads
+//
+// in:"body=stream" binds the field to an io.ReadCloser pointing straight at
+// r.Body, and in:"body=multipart" binds it to a *multipart.Reader, both
+// skipping httpin's buffered decode so a multi-GB upload never hits memory:
+//
+//	type UploadInput struct {
+//	    Upload io.ReadCloser `in:"body=stream"`
+//	}
+//
+//	type UploadFormInput struct {
+//	    Parts *multipart.Reader `in:"body=multipart"`
+//	}
+//
+// Middleware guarantees the body hasn't been read before the handler runs,
+// and excludes these fields from validator.Struct so validating the rest of
+// the input doesn't consume the stream. The handler owns Close.
+//
 // # Error Handling
 //
-// Default error handler returns HTTP 400 with error message.
-// Customize per-middleware or globally:
+// The default error handler is ProblemDetailsHandler, which renders RFC 7807
+// application/problem+json. Validation failures (422) include one entry per
+// failed field:
+//
+//	{
+//	  "type": "https://valmid.dev/validation-error",
+//	  "title": "Validation failed",
+//	  "status": 422,
+//	  "errors": [{"field": "Body.Name", "namespace": "CreateUserInput.Body.Name", "tag": "min", "param": "3", "message": "..."}]
+//	}
+//
+// Binding failures (400) report the source and field that couldn't be bound:
+//
+//	{"type": "https://valmid.dev/binding-error", "title": "Request binding failed", "status": 400, "source": "body", "field": "Body"}
+//
+// Use ErrorKind(err) to branch on KindValidation vs KindBinding without
+// type-switching. Customize per-middleware or globally:
 //
 //	// Per-middleware
 //	valmid.Middleware[Input](
@@ -81,6 +134,48 @@
 //	// Global default
 //	valmid.SetErrorHandler(myErrorHandler)
 //
+// Plug in a locale-aware message renderer for validation errors with
+// SetFieldTranslator, e.g. backed by go-playground/validator's
+// universal-translator package. See Localization for a ready-made one.
+//
+// # Localization
+//
+// SetTranslator and WithLocale translate validation messages with
+// go-playground/validator's universal-translator package, without giving up
+// ProblemDetailsHandler's RFC 7807 shape:
+//
+//	enLocale := en.New()
+//	uni := ut.New(enLocale, enLocale, es.New())
+//	valmid.RegisterEnglishTranslations(uni)
+//	valmid.RegisterSpanishTranslations(uni)
+//	valmid.SetTranslator(uni)
+//
+//	mux.Handle("POST /users/{id}",
+//	    valmid.Middleware[CreateUserInput](valmid.WithLocale(nil))(next),
+//	)
+//
+// WithLocale(nil) (or omitting it) picks a locale from the request's
+// Accept-Language header; pass a LocaleFunc to extract it some other way,
+// e.g. from a path parameter or session. Each ValidationError's Message is
+// translated via fe.Translate(trans) when a Translator is found for the
+// resolved locale, falling back to SetFieldTranslator's FieldTranslator
+// otherwise. ValidationError also carries Namespace, FieldError's untrimmed
+// dotted path (e.g. "CreateUserInput.Body.Name"), alongside the already
+// request-relative Field.
+//
+// RegisterEnglishTranslations and RegisterSpanishTranslations wrap the
+// upstream en/es translation packages; RegisterFrenchTranslations and
+// RegisterGermanTranslations do the same for fr/de. Wiring up any other
+// locale validator/v10/translations ships is the same two lines, registered
+// against the *validator.Validate passed to valmid.SetValidator (or the
+// package default if SetValidator was never called), since that's the
+// instance whose FieldErrors get translated:
+//
+//	v := validator.New()
+//	valmid.SetValidator(v)
+//	trans, _ := uni.GetTranslator("ja")
+//	ja.RegisterDefaultTranslations(v, trans)
+//
 // # Custom Validator
 //
 // Register custom validation rules:
@@ -89,6 +184,20 @@
 //	v.RegisterValidation("customrule", customFunc)
 //	valmid.SetValidator(v)
 //
+// # OpenAPI
+//
+// The openapi subpackage generates an OpenAPI 3.1 document from routes
+// registered with openapi.Route, by walking the same `in:` and `validate:`
+// tags Middleware uses:
+//
+//	spec := openapi.NewSpec(openapi.Info{Title: "Users API", Version: "1.0.0"})
+//	openapi.Route[CreateUserInput](spec, "POST", "/users/{id}",
+//	    openapi.Responds[UserBody](200),
+//	)
+//	mux.Handle("GET /openapi.json", spec)
+//
+// See the openapi package doc for details.
+//
 // [httpin]: https://github.com/ggicci/httpin
 // [go-playground/validator]: https://github.com/go-playground/validator
 package valmid
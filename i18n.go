@@ -0,0 +1,92 @@
+package valmid
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"strings"
+
+	ut "github.com/go-playground/universal-translator"
+)
+
+// LocaleFunc picks the locale to translate a request's validation errors
+// into, e.g. from its Accept-Language header. See WithLocale.
+type LocaleFunc func(r *http.Request) string
+
+// WithLocale sets the locale extractor this middleware uses to pick a
+// Translator (see SetTranslator) for validation errors. The default, used
+// when WithLocale isn't set, parses the request's Accept-Language header.
+func WithLocale(f LocaleFunc) Option {
+	return func(o *options) {
+		o.locale = f
+	}
+}
+
+// defaultLocaleFunc picks the Accept-Language tag with the highest "q"
+// weight, e.g. "es" out of "es;q=0.9,en;q=0.8". It doesn't attempt full
+// RFC 4647 range matching; translatorFor falls back from a regional tag
+// like "es-MX" to its parent "es".
+func defaultLocaleFunc(r *http.Request) string {
+	header := r.Header.Get("Accept-Language")
+	if header == "" {
+		return ""
+	}
+
+	best, bestQ := "", -1.0
+	for _, part := range strings.Split(header, ",") {
+		tag, qParam, _ := strings.Cut(strings.TrimSpace(part), ";")
+		tag = strings.TrimSpace(tag)
+		if tag == "" || tag == "*" {
+			continue
+		}
+
+		q := 1.0
+		if raw, ok := strings.CutPrefix(strings.TrimSpace(qParam), "q="); ok {
+			if parsed, err := strconv.ParseFloat(raw, 64); err == nil {
+				q = parsed
+			}
+		}
+		if q > bestQ {
+			best, bestQ = tag, q
+		}
+	}
+	return best
+}
+
+// localeContextKey is the context key withRequestLocale stores a request's
+// resolved locale under, for ProblemDetailsHandler to read back via
+// translatorFor.
+type localeContextKey struct{}
+
+// withRequestLocale resolves r's locale via o.locale (or defaultLocaleFunc)
+// and stashes it in r's context for ProblemDetailsHandler to pick up.
+func withRequestLocale(r *http.Request, o *options) *http.Request {
+	f := o.locale
+	if f == nil {
+		f = defaultLocaleFunc
+	}
+	locale := f(r)
+	if locale == "" {
+		return r
+	}
+	return r.WithContext(context.WithValue(r.Context(), localeContextKey{}, locale))
+}
+
+// translatorFor looks up the Translator for r's resolved locale, if
+// SetTranslator has been called and the locale (or a parent, e.g. "es" for
+// "es-MX") is registered with it.
+func translatorFor(r *http.Request) (ut.Translator, bool) {
+	uni := getTranslator()
+	if uni == nil {
+		return nil, false
+	}
+	locale, ok := r.Context().Value(localeContextKey{}).(string)
+	if !ok {
+		return nil, false
+	}
+	locales := []string{locale}
+	if parent, _, ok := strings.Cut(locale, "-"); ok {
+		locales = append(locales, parent)
+	}
+	return uni.FindTranslator(locales...)
+}
@@ -0,0 +1,98 @@
+package valmid_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/go-playground/locales/en"
+	"github.com/go-playground/locales/es"
+	ut "github.com/go-playground/universal-translator"
+	"github.com/iamolegga/valmid"
+)
+
+func TestSetTranslator_PicksLocaleFromAcceptLanguage(t *testing.T) {
+	enLocale := en.New()
+	uni := ut.New(enLocale, enLocale, es.New())
+	if err := valmid.RegisterEnglishTranslations(uni); err != nil {
+		t.Fatalf("failed to register English translations: %v", err)
+	}
+	if err := valmid.RegisterSpanishTranslations(uni); err != nil {
+		t.Fatalf("failed to register Spanish translations: %v", err)
+	}
+	valmid.SetTranslator(uni)
+	defer valmid.SetTranslator(nil)
+
+	handler := valmid.Middleware[Input]()(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		t.Fatal("should not be called")
+	}))
+
+	req := httptest.NewRequest("POST", "/42", strings.NewReader(`{"name":"Jo"}`))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("X-Token", "secret")
+	req.Header.Set("Accept-Language", "es;q=0.9,en;q=0.5")
+	req.SetPathValue("id", "42")
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+
+	var body struct {
+		Errors []valmid.ValidationError `json:"errors"`
+	}
+	if err := json.Unmarshal(rec.Body.Bytes(), &body); err != nil {
+		t.Fatalf("failed to unmarshal problem details: %v", err)
+	}
+	if len(body.Errors) != 1 {
+		t.Fatalf("expected 1 validation error, got %+v", body.Errors)
+	}
+	if body.Errors[0].Namespace != "Input.Body.Name" {
+		t.Errorf("expected full namespace, got %q", body.Errors[0].Namespace)
+	}
+	if !strings.Contains(body.Errors[0].Message, "Name debe tener al menos") {
+		t.Errorf("expected translated Spanish message, got %q", body.Errors[0].Message)
+	}
+}
+
+func TestWithLocale_CustomExtractor(t *testing.T) {
+	enLocale := en.New()
+	uni := ut.New(enLocale, enLocale, es.New())
+	if err := valmid.RegisterSpanishTranslations(uni); err != nil {
+		t.Fatalf("failed to register Spanish translations: %v", err)
+	}
+	valmid.SetTranslator(uni)
+	defer valmid.SetTranslator(nil)
+
+	handler := valmid.Middleware[Input](valmid.WithLocale(func(r *http.Request) string {
+		return r.URL.Query().Get("locale")
+	}))(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		t.Fatal("should not be called")
+	}))
+
+	req := httptest.NewRequest("POST", "/42?locale=es", strings.NewReader(`{"name":"Jo"}`))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("X-Token", "secret")
+	req.SetPathValue("id", "42")
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+
+	var body struct {
+		Errors []valmid.ValidationError `json:"errors"`
+	}
+	if err := json.Unmarshal(rec.Body.Bytes(), &body); err != nil {
+		t.Fatalf("failed to unmarshal problem details: %v", err)
+	}
+	if len(body.Errors) != 1 {
+		t.Fatalf("expected 1 validation error, got %+v", body.Errors)
+	}
+	if !strings.Contains(body.Errors[0].Message, "Name debe tener al menos") {
+		t.Errorf("expected translated Spanish message via custom locale extractor, got %+v", body.Errors)
+	}
+}
+
+func TestRegisterSpanishTranslations_UnregisteredLocale(t *testing.T) {
+	uni := ut.New(en.New())
+	if err := valmid.RegisterSpanishTranslations(uni); err == nil {
+		t.Error("expected an error registering Spanish translations into a uni with no Spanish Translator")
+	}
+}
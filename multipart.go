@@ -0,0 +1,166 @@
+package valmid
+
+import (
+	"fmt"
+	"mime/multipart"
+	"net/http"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// multipartReaderType is *multipart.Reader, the only field type
+// in:"body=multipart" supports.
+var multipartReaderType = reflect.TypeOf((*multipart.Reader)(nil))
+
+// multipartBinder hand-binds an input struct's path/query/header fields and
+// its in:"body=multipart" field straight from the request, bypassing
+// httpin.Core.Decode entirely. See the comment on autoBodyDirective in
+// body.go for why: httpin always buffers multipart/form-data bodies before
+// running any field directive, which defeats streaming.
+type multipartBinder struct {
+	t            reflect.Type
+	multipartIdx int
+	fields       []scalarField
+}
+
+// scalarField is a path, query, or header field bound by hand. Binding
+// alongside body=multipart is intentionally limited to these simple
+// sources and kinds; anything else is rejected when the binder is built,
+// the same way Middleware panics on an unsupported input type up front.
+// Its "required" and "default=" sub-directives are still honored, since
+// httpin never sees these fields to enforce them itself.
+type scalarField struct {
+	index      int
+	in         string // "path", "query", or "header"
+	name       string
+	required   bool
+	def        string
+	hasDefault bool
+}
+
+// newMultipartBinder builds a multipartBinder for t, or returns an error if
+// t has a field Middleware can't hand-bind this way.
+func newMultipartBinder(t reflect.Type, multipartIdx int) (*multipartBinder, error) {
+	if ft := t.Field(multipartIdx).Type; ft != multipartReaderType {
+		return nil, fmt.Errorf("field %q: in:\"body=multipart\" requires a *multipart.Reader field, got %s",
+			t.Field(multipartIdx).Name, ft)
+	}
+
+	b := &multipartBinder{t: t, multipartIdx: multipartIdx}
+	for i := 0; i < t.NumField(); i++ {
+		if i == multipartIdx {
+			continue
+		}
+		f := t.Field(i)
+		tag := f.Tag.Get("in")
+		if tag == "" {
+			continue
+		}
+
+		directives := strings.Split(tag, ";")
+		source, name, _ := strings.Cut(directives[0], "=")
+		switch source {
+		case "path", "query", "header":
+		default:
+			return nil, fmt.Errorf("field %q: in:%q isn't supported alongside body=multipart; only path, query, and header sources are", f.Name, tag)
+		}
+
+		switch f.Type.Kind() {
+		case reflect.String, reflect.Bool,
+			reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+			reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		default:
+			return nil, fmt.Errorf("field %q: type %s isn't supported alongside body=multipart; only strings, integers, and bools are", f.Name, f.Type)
+		}
+
+		sf := scalarField{index: i, in: source, name: name}
+		for _, d := range directives[1:] {
+			dname, dval, _ := strings.Cut(d, "=")
+			switch dname {
+			case "required":
+				sf.required = true
+			case "default":
+				sf.def, sf.hasDefault = dval, true
+			}
+		}
+		b.fields = append(b.fields, sf)
+	}
+	return b, nil
+}
+
+// decode opens a streaming *multipart.Reader over r's body and binds it,
+// along with any path/query/header fields, into a new instance of the
+// binder's struct type. The body is guaranteed not to have been read
+// before this returns; closing parts read from it is the handler's
+// responsibility.
+func (b *multipartBinder) decode(r *http.Request) (any, error) {
+	mr, err := r.MultipartReader()
+	if err != nil {
+		return nil, fmt.Errorf("valmid: failed to open multipart reader: %w", err)
+	}
+
+	out := reflect.New(b.t)
+	elem := out.Elem()
+
+	for _, sf := range b.fields {
+		raw, ok := sf.lookup(r)
+		if !ok {
+			switch {
+			case sf.hasDefault:
+				raw = sf.def
+			case sf.required:
+				return nil, fmt.Errorf("valmid: field %q: missing required %s %q", b.t.Field(sf.index).Name, sf.in, sf.name)
+			default:
+				continue
+			}
+		}
+		if err := setScalarField(elem.Field(sf.index), raw); err != nil {
+			return nil, fmt.Errorf("valmid: field %q: %w", b.t.Field(sf.index).Name, err)
+		}
+	}
+
+	elem.Field(b.multipartIdx).Set(reflect.ValueOf(mr))
+	return out.Interface(), nil
+}
+
+func (sf scalarField) lookup(r *http.Request) (string, bool) {
+	switch sf.in {
+	case "path":
+		v := r.PathValue(sf.name)
+		return v, v != ""
+	case "query":
+		q := r.URL.Query()
+		return q.Get(sf.name), q.Has(sf.name)
+	case "header":
+		v := r.Header.Get(sf.name)
+		return v, v != ""
+	}
+	return "", false
+}
+
+func setScalarField(field reflect.Value, raw string) error {
+	switch field.Kind() {
+	case reflect.String:
+		field.SetString(raw)
+	case reflect.Bool:
+		v, err := strconv.ParseBool(raw)
+		if err != nil {
+			return err
+		}
+		field.SetBool(v)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		n, err := strconv.ParseInt(raw, 10, 64)
+		if err != nil {
+			return err
+		}
+		field.SetInt(n)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		n, err := strconv.ParseUint(raw, 10, 64)
+		if err != nil {
+			return err
+		}
+		field.SetUint(n)
+	}
+	return nil
+}
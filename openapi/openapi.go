@@ -0,0 +1,143 @@
+// Package openapi generates an OpenAPI 3.1 document from routes registered
+// with Route, by walking the same `in:` and `validate:` struct tags that
+// [valmid.Middleware] uses to bind and validate requests.
+//
+//	spec := openapi.NewSpec(openapi.Info{Title: "Users API", Version: "1.0.0"})
+//	openapi.Route[CreateUserInput](spec, "POST", "/users/{id}",
+//	    openapi.Responds[UserBody](200),
+//	)
+//	mux.Handle("GET /openapi.json", spec)
+//
+// A Spec is purely additive documentation: nothing about valmid.Middleware
+// or net/http routing depends on it, so callers can register routes with it
+// incrementally, or not at all.
+package openapi
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// Info holds an OpenAPI document's top-level metadata.
+type Info struct {
+	Title       string
+	Version     string
+	Description string
+}
+
+// Spec accumulates routes registered with Route and renders them as an
+// OpenAPI 3.1 document via Document or ServeHTTP. The zero value is not
+// usable; create one with NewSpec.
+type Spec struct {
+	info   Info
+	schema *schemaBuilder
+
+	mu     sync.Mutex
+	routes []*routeSpec
+}
+
+// NewSpec creates an empty Spec described by info.
+func NewSpec(info Info) *Spec {
+	return &Spec{
+		info:   info,
+		schema: newSchemaBuilder(),
+	}
+}
+
+// Document renders the routes registered so far as an OpenAPI 3.1 document.
+func (s *Spec) Document() map[string]any {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	paths := map[string]any{}
+	for _, rs := range s.routes {
+		item, _ := paths[rs.path].(map[string]any)
+		if item == nil {
+			item = map[string]any{}
+			paths[rs.path] = item
+		}
+		item[strings.ToLower(rs.method)] = operationFor(rs)
+	}
+
+	schemas := map[string]any{}
+	for name, schema := range s.schema.components {
+		schemas[name] = schema
+	}
+
+	return map[string]any{
+		"openapi": "3.1.0",
+		"info": map[string]any{
+			"title":       s.info.Title,
+			"version":     s.info.Version,
+			"description": s.info.Description,
+		},
+		"paths":      paths,
+		"components": map[string]any{"schemas": schemas},
+	}
+}
+
+// ServeHTTP writes the OpenAPI document as JSON, for mounting at a path
+// like /openapi.json:
+//
+//	mux.Handle("GET /openapi.json", spec)
+func (s *Spec) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(s.Document())
+}
+
+// SwaggerUIHandler redirects to a hosted Swagger UI pointed at specURL (the
+// path ServeHTTP is mounted at), for a human-browsable docs page:
+//
+//	mux.Handle("GET /openapi.json", spec)
+//	mux.Handle("GET /docs", spec.SwaggerUIHandler("/openapi.json"))
+func (s *Spec) SwaggerUIHandler(specURL string) http.Handler {
+	target := "https://petstore.swagger.io/?url=" + url.QueryEscape(specURL)
+	return http.RedirectHandler(target, http.StatusFound)
+}
+
+// operationFor renders rs as an OpenAPI Operation Object.
+func operationFor(rs *routeSpec) map[string]any {
+	op := map[string]any{}
+
+	if len(rs.parameters) > 0 {
+		params := make([]map[string]any, len(rs.parameters))
+		for i, p := range rs.parameters {
+			params[i] = map[string]any{
+				"name":     p.name,
+				"in":       string(p.in),
+				"required": p.required,
+				"schema":   p.schema,
+			}
+		}
+		op["parameters"] = params
+	}
+
+	if rs.requestBody != nil {
+		op["requestBody"] = map[string]any{
+			"required": rs.requestBody.required,
+			"content": map[string]any{
+				"application/json": map[string]any{"schema": rs.requestBody.schema},
+			},
+		}
+	}
+
+	responses := map[string]any{}
+	for status, resp := range rs.responses {
+		responses[strconv.Itoa(status)] = map[string]any{
+			"description": resp.description,
+			"content": map[string]any{
+				"application/json": map[string]any{"schema": resp.schema},
+			},
+		}
+	}
+	if len(responses) == 0 {
+		responses["default"] = map[string]any{"description": "default response"}
+	}
+	op["responses"] = responses
+
+	return op
+}
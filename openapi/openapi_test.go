@@ -0,0 +1,160 @@
+package openapi_test
+
+import (
+	"encoding/json"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/iamolegga/valmid/openapi"
+)
+
+type UserBody struct {
+	Name string `json:"name" validate:"required,min=3,max=100"`
+	Role string `json:"role" validate:"required,oneof=admin user"`
+}
+
+type CreateUserInput struct {
+	ID    int       `in:"path=id" validate:"gt=0"`
+	Page  int       `in:"query=page;default=1"`
+	Token string    `in:"header=X-Token" validate:"required"`
+	Body  *UserBody `in:"body=json" validate:"required"`
+}
+
+func TestRoute_Parameters(t *testing.T) {
+	spec := openapi.NewSpec(openapi.Info{Title: "Users API", Version: "1.0.0"})
+	openapi.Route[CreateUserInput](spec, "POST", "/users/{id}", openapi.Responds[UserBody](200))
+
+	doc := spec.Document()
+	paths, _ := doc["paths"].(map[string]any)
+	item, _ := paths["/users/{id}"].(map[string]any)
+	op, _ := item["post"].(map[string]any)
+	if op == nil {
+		t.Fatalf("expected a POST operation at /users/{id}, got %+v", paths)
+	}
+
+	params, _ := op["parameters"].([]map[string]any)
+	if len(params) != 3 {
+		t.Fatalf("expected 3 parameters (path, query, header), got %d: %+v", len(params), params)
+	}
+
+	byName := map[string]map[string]any{}
+	for _, p := range params {
+		byName[p["name"].(string)] = p
+	}
+
+	id, ok := byName["id"]
+	if !ok || id["in"] != "path" || id["required"] != true {
+		t.Errorf("unexpected id parameter: %+v", id)
+	}
+
+	page, ok := byName["page"]
+	if !ok || page["in"] != "query" || page["required"] != false {
+		t.Errorf("unexpected page parameter: %+v", page)
+	}
+
+	token, ok := byName["X-Token"]
+	if !ok || token["in"] != "header" || token["required"] != true {
+		t.Errorf("unexpected token parameter: %+v", token)
+	}
+}
+
+func TestRoute_RequestBodyAndResponses(t *testing.T) {
+	spec := openapi.NewSpec(openapi.Info{Title: "Users API", Version: "1.0.0"})
+	openapi.Route[CreateUserInput](spec, "POST", "/users/{id}", openapi.Responds[UserBody](200))
+
+	doc := spec.Document()
+
+	b, err := json.Marshal(doc)
+	if err != nil {
+		t.Fatalf("failed to marshal document: %v", err)
+	}
+
+	var parsed struct {
+		Paths map[string]struct {
+			Post struct {
+				RequestBody struct {
+					Required bool `json:"required"`
+					Content  struct {
+						ApplicationJSON struct {
+							Schema map[string]any `json:"schema"`
+						} `json:"application/json"`
+					} `json:"content"`
+				} `json:"requestBody"`
+				Responses map[string]struct {
+					Description string `json:"description"`
+				} `json:"responses"`
+			} `json:"post"`
+		} `json:"paths"`
+		Components struct {
+			Schemas map[string]struct {
+				Properties map[string]map[string]any `json:"properties"`
+				Required   []string                  `json:"required"`
+			} `json:"schemas"`
+		} `json:"components"`
+	}
+	if err := json.Unmarshal(b, &parsed); err != nil {
+		t.Fatalf("failed to unmarshal document: %v", err)
+	}
+
+	post := parsed.Paths["/users/{id}"].Post
+	if !post.RequestBody.Required {
+		t.Error("expected requestBody to be required")
+	}
+	if ref, ok := post.RequestBody.Content.ApplicationJSON.Schema["$ref"]; ok != true || ref != "#/components/schemas/UserBody" {
+		t.Errorf("expected requestBody schema to $ref UserBody, got %+v", post.RequestBody.Content.ApplicationJSON.Schema)
+	}
+	if _, ok := post.Responses["200"]; !ok {
+		t.Errorf("expected a 200 response, got %+v", post.Responses)
+	}
+
+	userBody, ok := parsed.Components.Schemas["UserBody"]
+	if !ok {
+		t.Fatalf("expected components/schemas/UserBody, got %+v", parsed.Components.Schemas)
+	}
+	if len(userBody.Required) != 2 {
+		t.Errorf("expected both Name and Role required, got %+v", userBody.Required)
+	}
+	nameSchema := userBody.Properties["name"]
+	if nameSchema["minLength"] != float64(3) || nameSchema["maxLength"] != float64(100) {
+		t.Errorf("unexpected name schema: %+v", nameSchema)
+	}
+	roleSchema := userBody.Properties["role"]
+	enum, _ := roleSchema["enum"].([]any)
+	if len(enum) != 2 || enum[0] != "admin" || enum[1] != "user" {
+		t.Errorf("unexpected role schema: %+v", roleSchema)
+	}
+}
+
+func TestSpec_ServeHTTP(t *testing.T) {
+	spec := openapi.NewSpec(openapi.Info{Title: "Users API", Version: "1.0.0"})
+	openapi.Route[CreateUserInput](spec, "POST", "/users/{id}", openapi.Responds[UserBody](200))
+
+	req := httptest.NewRequest("GET", "/openapi.json", nil)
+	rec := httptest.NewRecorder()
+	spec.ServeHTTP(rec, req)
+
+	if ct := rec.Header().Get("Content-Type"); ct != "application/json" {
+		t.Errorf("expected application/json, got %q", ct)
+	}
+
+	var doc map[string]any
+	if err := json.Unmarshal(rec.Body.Bytes(), &doc); err != nil {
+		t.Fatalf("failed to unmarshal response: %v", err)
+	}
+	if doc["openapi"] != "3.1.0" {
+		t.Errorf("expected openapi 3.1.0, got %+v", doc["openapi"])
+	}
+}
+
+func TestSpec_SwaggerUIHandler(t *testing.T) {
+	spec := openapi.NewSpec(openapi.Info{Title: "Users API", Version: "1.0.0"})
+
+	req := httptest.NewRequest("GET", "/docs", nil)
+	rec := httptest.NewRecorder()
+	spec.SwaggerUIHandler("/openapi.json").ServeHTTP(rec, req)
+
+	loc := rec.Header().Get("Location")
+	if loc != "https://petstore.swagger.io/?url=%2Fopenapi.json" {
+		t.Errorf("unexpected redirect target: %q", loc)
+	}
+}
@@ -0,0 +1,170 @@
+package openapi
+
+import (
+	"net/http"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// paramIn is where an OpenAPI parameter is located.
+type paramIn string
+
+const (
+	inPath   paramIn = "path"
+	inQuery  paramIn = "query"
+	inHeader paramIn = "header"
+)
+
+// routeSpec is one route registered with Route: its path/query/header
+// parameters, request body (if any), and declared responses.
+type routeSpec struct {
+	method      string
+	path        string
+	parameters  []parameter
+	requestBody *requestBody
+	responses   map[int]response
+}
+
+type parameter struct {
+	name     string
+	in       paramIn
+	required bool
+	schema   jsonSchema
+}
+
+type requestBody struct {
+	required bool
+	schema   jsonSchema
+}
+
+type response struct {
+	description string
+	schema      jsonSchema
+}
+
+// ResponseOption configures one response entry on a route registered with
+// Route. Build one with Responds.
+type ResponseOption func(b *schemaBuilder, rs *routeSpec)
+
+// Responds declares that the route can respond with status and a body
+// shaped like T, e.g. Responds[UserBody](200).
+func Responds[T any](status int) ResponseOption {
+	return func(b *schemaBuilder, rs *routeSpec) {
+		var zero T
+		rs.responses[status] = response{
+			description: http.StatusText(status),
+			schema:      b.schemaFor(reflect.TypeOf(zero)),
+		}
+	}
+}
+
+// Route registers a route whose input is bound and validated by
+// valmid.Middleware[T] at method and path (a net/http 1.22 pattern like
+// "/users/{id}"), walking T's `in:` and `validate:` tags to build its
+// parameters and request body. It returns s for chaining.
+//
+// Route is a free function, not a method, because Go methods can't carry
+// their own type parameters — T has to be supplied at the call site, e.g.
+// openapi.Route[CreateUserInput](spec, "POST", "/users/{id}", ...).
+//
+// Registering a route with Route is entirely optional: valmid.Middleware
+// and net/http routing don't depend on it, so a Spec can be adopted
+// incrementally, or skipped altogether.
+func Route[T any](s *Spec, method, path string, opts ...ResponseOption) *Spec {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var zero T
+	t := reflect.TypeOf(zero)
+	for t != nil && t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+
+	rs := &routeSpec{
+		method:    strings.ToUpper(method),
+		path:      path,
+		responses: map[int]response{},
+	}
+
+	if t != nil && t.Kind() == reflect.Struct {
+		for i := 0; i < t.NumField(); i++ {
+			f := t.Field(i)
+			if f.IsExported() {
+				addField(s.schema, rs, f)
+			}
+		}
+	}
+
+	for _, opt := range opts {
+		opt(s.schema, rs)
+	}
+
+	s.routes = append(s.routes, rs)
+	return s
+}
+
+// addField adds rs's parameter or request body entry for f, based on its
+// `in:` tag. Sources Route doesn't represent in OpenAPI (form fields, or a
+// custom httpin directive) are left out of the spec; valmid.Middleware still
+// binds them normally, they just won't be documented.
+func addField(b *schemaBuilder, rs *routeSpec, f reflect.StructField) {
+	tag := f.Tag.Get("in")
+	if tag == "" {
+		return
+	}
+
+	// A field may list several fallback sources, e.g.
+	// "query=token;header=X-Token"; only the first is documented.
+	directives := strings.Split(tag, ";")
+	source, name, _ := strings.Cut(directives[0], "=")
+
+	switch source {
+	case "path", "query", "header":
+		schema := b.schemaFor(f.Type)
+		applyValidateTag(schema, f.Tag.Get("validate"))
+
+		p := parameter{
+			name:     name,
+			in:       paramIn(source),
+			required: source == "path" || hasValidateTag(f.Tag.Get("validate"), "required"),
+			schema:   schema,
+		}
+		for _, d := range directives[1:] {
+			dname, dval, _ := strings.Cut(d, "=")
+			switch dname {
+			case "required":
+				p.required = true
+			case "default":
+				schema["default"] = typedDefault(schema, dval)
+			}
+		}
+		rs.parameters = append(rs.parameters, p)
+	case "body":
+		rs.requestBody = &requestBody{
+			required: hasValidateTag(f.Tag.Get("validate"), "required"),
+			schema:   b.schemaFor(f.Type),
+		}
+	}
+}
+
+// typedDefault parses raw as schema's declared type, falling back to the raw
+// string if it doesn't match (e.g. an unparseable default), since an
+// OpenAPI default should be valid against its own schema.
+func typedDefault(schema jsonSchema, raw string) any {
+	switch schema["type"] {
+	case "integer":
+		if n, err := strconv.ParseInt(raw, 10, 64); err == nil {
+			return n
+		}
+	case "number":
+		if n, err := strconv.ParseFloat(raw, 64); err == nil {
+			return n
+		}
+	case "boolean":
+		if v, err := strconv.ParseBool(raw); err == nil {
+			return v
+		}
+	}
+	return raw
+}
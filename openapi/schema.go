@@ -0,0 +1,187 @@
+package openapi
+
+import (
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// jsonSchema is a JSON Schema document. OpenAPI 3.1 schemas are themselves
+// valid JSON Schema (draft 2020-12), so this is the same map[string]any both
+// for inline parameter schemas and for entries in components/schemas.
+type jsonSchema map[string]any
+
+// schemaBuilder converts Go struct types into JSON Schema, registering each
+// named struct type once in components and referencing it by name on
+// repeat use so e.g. a Body type shared by several routes isn't duplicated.
+type schemaBuilder struct {
+	components map[string]jsonSchema
+}
+
+func newSchemaBuilder() *schemaBuilder {
+	return &schemaBuilder{components: map[string]jsonSchema{}}
+}
+
+// schemaFor returns a JSON Schema for t, recursing into nested structs,
+// slices, and maps.
+func (b *schemaBuilder) schemaFor(t reflect.Type) jsonSchema {
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+
+	switch t.Kind() {
+	case reflect.Struct:
+		return b.structSchema(t)
+	case reflect.Slice, reflect.Array:
+		return jsonSchema{"type": "array", "items": b.schemaFor(t.Elem())}
+	case reflect.Map:
+		return jsonSchema{"type": "object", "additionalProperties": b.schemaFor(t.Elem())}
+	case reflect.String:
+		return jsonSchema{"type": "string"}
+	case reflect.Bool:
+		return jsonSchema{"type": "boolean"}
+	case reflect.Float32, reflect.Float64:
+		return jsonSchema{"type": "number"}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return jsonSchema{"type": "integer"}
+	default:
+		return jsonSchema{}
+	}
+}
+
+// structSchema registers t's object schema in components under its type
+// name and returns a $ref to it. Anonymous struct types (no name, e.g.
+// struct{ ... } literals) are inlined instead since there's no name to key
+// components on.
+func (b *schemaBuilder) structSchema(t reflect.Type) jsonSchema {
+	if t.Name() == "" {
+		return b.buildObjectSchema(t)
+	}
+	if _, ok := b.components[t.Name()]; !ok {
+		b.components[t.Name()] = jsonSchema{} // reserve the name to break cycles
+		b.components[t.Name()] = b.buildObjectSchema(t)
+	}
+	return jsonSchema{"$ref": "#/components/schemas/" + t.Name()}
+}
+
+// buildObjectSchema converts t's exported fields into an object schema,
+// using each field's json tag for the property name and validate tag for
+// JSON Schema constraints.
+func (b *schemaBuilder) buildObjectSchema(t reflect.Type) jsonSchema {
+	props := jsonSchema{}
+	var required []string
+
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		if !f.IsExported() {
+			continue
+		}
+		name := jsonFieldName(f)
+		if name == "-" {
+			continue
+		}
+
+		fieldSchema := b.schemaFor(f.Type)
+		applyValidateTag(fieldSchema, f.Tag.Get("validate"))
+		props[name] = fieldSchema
+
+		if hasValidateTag(f.Tag.Get("validate"), "required") {
+			required = append(required, name)
+		}
+	}
+
+	schema := jsonSchema{"type": "object", "properties": props}
+	if len(required) > 0 {
+		schema["required"] = required
+	}
+	return schema
+}
+
+// jsonFieldName resolves a struct field's JSON Schema property name from its
+// json tag, falling back to the Go field name.
+func jsonFieldName(f reflect.StructField) string {
+	tag := f.Tag.Get("json")
+	if tag == "" {
+		return f.Name
+	}
+	name, _, _ := strings.Cut(tag, ",")
+	if name == "" {
+		return f.Name
+	}
+	return name
+}
+
+// splitValidateTag splits a validate tag into its comma-separated rules,
+// e.g. "required,min=3" into ["required", "min=3"].
+func splitValidateTag(tag string) []string {
+	if tag == "" {
+		return nil
+	}
+	return strings.Split(tag, ",")
+}
+
+// hasValidateTag reports whether tag contains the rule named want, ignoring
+// any "=param" suffix.
+func hasValidateTag(tag, want string) bool {
+	for _, rule := range splitValidateTag(tag) {
+		name, _, _ := strings.Cut(rule, "=")
+		if name == want {
+			return true
+		}
+	}
+	return false
+}
+
+// applyValidateTag maps the go-playground/validator rules min, max, gt, gte,
+// lt, lte, oneof, and email onto the matching JSON Schema keyword, mutating
+// schema in place. required isn't handled here: callers check it with
+// hasValidateTag, since it belongs on the parent object or the parameter.
+// Unrecognized rules (e.g. custom validators) are left unmapped.
+func applyValidateTag(schema jsonSchema, tag string) {
+	for _, rule := range splitValidateTag(tag) {
+		name, param, _ := strings.Cut(rule, "=")
+		switch name {
+		case "min":
+			applyBound(schema, "minLength", "minimum", param)
+		case "max":
+			applyBound(schema, "maxLength", "maximum", param)
+		case "gt":
+			applyNumeric(schema, "exclusiveMinimum", param)
+		case "gte":
+			applyNumeric(schema, "minimum", param)
+		case "lt":
+			applyNumeric(schema, "exclusiveMaximum", param)
+		case "lte":
+			applyNumeric(schema, "maximum", param)
+		case "oneof":
+			values := strings.Fields(param)
+			enum := make([]any, len(values))
+			for i, v := range values {
+				enum[i] = v
+			}
+			schema["enum"] = enum
+		case "email":
+			schema["format"] = "email"
+		}
+	}
+}
+
+// applyBound sets strKeyword for string schemas or numKeyword otherwise,
+// used by the min/max rules which mean length for strings and value bounds
+// for numbers.
+func applyBound(schema jsonSchema, strKeyword, numKeyword, param string) {
+	if schema["type"] == "string" {
+		if n, err := strconv.Atoi(param); err == nil {
+			schema[strKeyword] = n
+		}
+		return
+	}
+	applyNumeric(schema, numKeyword, param)
+}
+
+func applyNumeric(schema jsonSchema, keyword, param string) {
+	if n, err := strconv.ParseFloat(param, 64); err == nil {
+		schema[keyword] = n
+	}
+}
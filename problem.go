@@ -0,0 +1,235 @@
+package valmid
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/ggicci/httpin/core"
+	ut "github.com/go-playground/universal-translator"
+	"github.com/go-playground/validator/v10"
+)
+
+// Kind classifies the kind of failure behind a Middleware error, so custom
+// error handlers can branch on it instead of matching on concrete types or
+// error strings. See ErrorKind.
+type Kind int
+
+const (
+	// KindUnknown is returned for errors that are neither binding nor
+	// validation failures, e.g. an error from the binder Middleware uses
+	// for in:"body=multipart" inputs, which doesn't go through httpin.
+	KindUnknown Kind = iota
+	// KindBinding marks a failure to extract a value from the request
+	// (path, query, header, form, or body), as reported by httpin.
+	KindBinding
+	// KindValidation marks a struct validation failure reported by
+	// go-playground/validator.
+	KindValidation
+)
+
+// ErrorKind classifies err as a binding or validation failure. Custom error
+// handlers can use it instead of type-switching on validator.ValidationErrors
+// or httpin's error types directly.
+func ErrorKind(err error) Kind {
+	var ve validator.ValidationErrors
+	if errors.As(err, &ve) {
+		return KindValidation
+	}
+	var fe *core.InvalidFieldError
+	if errors.As(err, &fe) {
+		return KindBinding
+	}
+	return KindUnknown
+}
+
+// FieldTranslator renders a human-readable message for a single failed
+// validation. The default renders a generic "field failed 'tag'" message;
+// set a locale-aware one with SetFieldTranslator, e.g. one backed by
+// go-playground/validator's universal-translator package.
+type FieldTranslator func(validator.FieldError) string
+
+var fieldTranslator FieldTranslator = defaultFieldMessage
+
+// SetFieldTranslator sets the function ProblemDetailsHandler uses to render
+// each validator.FieldError's message.
+func SetFieldTranslator(t FieldTranslator) {
+	mu.Lock()
+	defer mu.Unlock()
+	fieldTranslator = t
+}
+
+func getFieldTranslator() FieldTranslator {
+	mu.RLock()
+	defer mu.RUnlock()
+	return fieldTranslator
+}
+
+func defaultFieldMessage(fe validator.FieldError) string {
+	return fmt.Sprintf("%s failed on the %q tag", fieldPath(fe), fe.Tag())
+}
+
+var translator *ut.UniversalTranslator
+
+// SetTranslator sets the UniversalTranslator ProblemDetailsHandler uses to
+// translate validation messages, keyed by the locale WithLocale resolves
+// for each request. Register the locales it knows about with
+// RegisterEnglishTranslations, RegisterSpanishTranslations, etc. first, or
+// its Translators won't have any messages to serve.
+//
+// When SetTranslator has been called and a Translator is found for a
+// request's locale, fe.Translate(trans) takes priority over
+// SetFieldTranslator's FieldTranslator for that request; the FieldTranslator
+// remains the fallback for requests with no locale match.
+func SetTranslator(uni *ut.UniversalTranslator) {
+	mu.Lock()
+	defer mu.Unlock()
+	translator = uni
+}
+
+func getTranslator() *ut.UniversalTranslator {
+	mu.RLock()
+	defer mu.RUnlock()
+	return translator
+}
+
+// fieldPath strips the leading struct type name from fe.Namespace(), e.g.
+// "CreateUserInput.Body.Name" becomes "Body.Name".
+func fieldPath(fe validator.FieldError) string {
+	_, path, found := strings.Cut(fe.Namespace(), ".")
+	if !found {
+		return fe.Namespace()
+	}
+	return path
+}
+
+// problemDetails is an RFC 7807 (application/problem+json) response body.
+type problemDetails struct {
+	Type   string            `json:"type"`
+	Title  string            `json:"title"`
+	Status int               `json:"status"`
+	Detail string            `json:"detail,omitempty"`
+	Source string            `json:"source,omitempty"`
+	Field  string            `json:"field,omitempty"`
+	Errors []ValidationError `json:"errors,omitempty"`
+}
+
+// ValidationError describes a single field's validation failure.
+type ValidationError struct {
+	Field     string `json:"field"`
+	Namespace string `json:"namespace"`
+	Tag       string `json:"tag"`
+	Param     string `json:"param,omitempty"`
+	Message   string `json:"message"`
+}
+
+// Error renders e as a single-line message, so ValidationError satisfies
+// the error interface.
+func (e ValidationError) Error() string {
+	return fmt.Sprintf("%s: %s", e.Namespace, e.Message)
+}
+
+// ValidationErrors is the structured, translated form of a
+// validator.ValidationErrors failure. Middleware wraps every validation
+// failure in one before calling the error handler, so a custom
+// ErrorHandlerFunc can get the translated, per-field messages via
+// errors.As(err, &ve) instead of depending on ProblemDetailsHandler or
+// re-running the translation itself. ErrorKind still reports
+// KindValidation for it, since Unwrap exposes the original
+// validator.ValidationErrors.
+type ValidationErrors struct {
+	Errors []ValidationError
+	cause  validator.ValidationErrors
+}
+
+// Error joins each field error's message.
+func (e *ValidationErrors) Error() string {
+	msgs := make([]string, len(e.Errors))
+	for i, fe := range e.Errors {
+		msgs[i] = fe.Error()
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// Unwrap exposes the validator.ValidationErrors e was built from, so
+// errors.As(err, &validator.ValidationErrors{}) and ErrorKind keep working
+// against a wrapped error.
+func (e *ValidationErrors) Unwrap() error {
+	return e.cause
+}
+
+// newValidationErrors translates ve's fields via the FieldTranslator or
+// Translator resolved for r (see SetFieldTranslator, SetTranslator) and
+// wraps the result as a *ValidationErrors.
+func newValidationErrors(r *http.Request, ve validator.ValidationErrors) *ValidationErrors {
+	translate := getFieldTranslator()
+	trans, hasTrans := translatorFor(r)
+	errs := make([]ValidationError, len(ve))
+	for i, fe := range ve {
+		message := translate(fe)
+		if hasTrans {
+			message = fe.Translate(trans)
+		}
+		errs[i] = ValidationError{
+			Field:     fieldPath(fe),
+			Namespace: fe.Namespace(),
+			Tag:       fe.Tag(),
+			Param:     fe.Param(),
+			Message:   message,
+		}
+	}
+	return &ValidationErrors{Errors: errs, cause: ve}
+}
+
+// ProblemDetailsHandler is the default ErrorHandlerFunc. It renders
+// validation failures as RFC 7807 application/problem+json with one entry
+// per failed field, and httpin binding failures with the source (path,
+// query, header, form, or body) and field that couldn't be bound.
+func ProblemDetailsHandler(w http.ResponseWriter, r *http.Request, err error) {
+	var ve *ValidationErrors
+	if !errors.As(err, &ve) {
+		// err didn't come from Middleware already wrapped (e.g. a caller
+		// invoking ProblemDetailsHandler directly); translate it ourselves.
+		var raw validator.ValidationErrors
+		if errors.As(err, &raw) {
+			ve = newValidationErrors(r, raw)
+		}
+	}
+	if ve != nil {
+		writeProblem(w, problemDetails{
+			Type:   "https://valmid.dev/validation-error",
+			Title:  "Validation failed",
+			Status: http.StatusUnprocessableEntity,
+			Errors: ve.Errors,
+		})
+		return
+	}
+
+	var fe *core.InvalidFieldError
+	if errors.As(err, &fe) {
+		writeProblem(w, problemDetails{
+			Type:   "https://valmid.dev/binding-error",
+			Title:  "Request binding failed",
+			Status: http.StatusBadRequest,
+			Detail: err.Error(),
+			Source: fe.Directive,
+			Field:  fe.Field,
+		})
+		return
+	}
+
+	writeProblem(w, problemDetails{
+		Type:   "https://valmid.dev/binding-error",
+		Title:  "Request binding failed",
+		Status: http.StatusBadRequest,
+		Detail: err.Error(),
+	})
+}
+
+func writeProblem(w http.ResponseWriter, p problemDetails) {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(p.Status)
+	_ = json.NewEncoder(w).Encode(p)
+}
@@ -0,0 +1,120 @@
+package valmid_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/iamolegga/valmid"
+)
+
+func TestProblemDetailsHandler_Validation(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.Handle("POST /users/{id}", valmid.Middleware[Input]()(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		t.Fatal("should not be called")
+	})))
+
+	req := httptest.NewRequest("POST", "/users/42", strings.NewReader(`{"name":"Jo"}`))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("X-Token", "secret")
+	rec := httptest.NewRecorder()
+	mux.ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusUnprocessableEntity {
+		t.Fatalf("expected 422, got %d", rec.Code)
+	}
+	if ct := rec.Header().Get("Content-Type"); ct != "application/problem+json" {
+		t.Errorf("expected application/problem+json, got %q", ct)
+	}
+
+	var body struct {
+		Type   string `json:"type"`
+		Status int    `json:"status"`
+		Errors []struct {
+			Field string `json:"field"`
+			Tag   string `json:"tag"`
+			Param string `json:"param"`
+		} `json:"errors"`
+	}
+	if err := json.Unmarshal(rec.Body.Bytes(), &body); err != nil {
+		t.Fatalf("failed to unmarshal problem details: %v", err)
+	}
+	if body.Status != 422 || len(body.Errors) != 1 {
+		t.Fatalf("unexpected problem details: %+v", body)
+	}
+	if body.Errors[0].Field != "Body.Name" || body.Errors[0].Tag != "min" || body.Errors[0].Param != "3" {
+		t.Errorf("unexpected field error: %+v", body.Errors[0])
+	}
+}
+
+func TestProblemDetailsHandler_Binding(t *testing.T) {
+	handler := valmid.Middleware[Input]()(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		t.Fatal("should not be called")
+	}))
+
+	req := httptest.NewRequest("POST", "/", strings.NewReader("not json"))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("X-Token", "secret")
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusBadRequest {
+		t.Fatalf("expected 400, got %d", rec.Code)
+	}
+
+	var body struct {
+		Type   string `json:"type"`
+		Status int    `json:"status"`
+	}
+	if err := json.Unmarshal(rec.Body.Bytes(), &body); err != nil {
+		t.Fatalf("failed to unmarshal problem details: %v", err)
+	}
+	if body.Type != "https://valmid.dev/binding-error" {
+		t.Errorf("unexpected problem type: %q", body.Type)
+	}
+}
+
+func TestErrorKind(t *testing.T) {
+	v := validator.New()
+	err := v.Struct(struct {
+		Name string `validate:"required"`
+	}{})
+
+	if valmid.ErrorKind(err) != valmid.KindValidation {
+		t.Errorf("expected KindValidation for validator.ValidationErrors")
+	}
+	if valmid.ErrorKind(nil) != valmid.KindUnknown {
+		t.Errorf("expected KindUnknown for nil error")
+	}
+}
+
+func TestSetFieldTranslator(t *testing.T) {
+	valmid.SetFieldTranslator(func(fe validator.FieldError) string {
+		return "custom: " + fe.Tag()
+	})
+	defer valmid.SetFieldTranslator(func(fe validator.FieldError) string {
+		path := fe.Namespace()
+		if _, rest, found := strings.Cut(path, "."); found {
+			path = rest
+		}
+		return fmt.Sprintf("%s failed on the %q tag", path, fe.Tag())
+	})
+
+	handler := valmid.Middleware[Input]()(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		t.Fatal("should not be called")
+	}))
+
+	req := httptest.NewRequest("POST", "/", strings.NewReader(`{"name":"Jo"}`))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("X-Token", "secret")
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+
+	if !strings.Contains(rec.Body.String(), "custom: min") {
+		t.Errorf("expected custom translated message, got %s", rec.Body.String())
+	}
+}
@@ -0,0 +1,156 @@
+package valmid_test
+
+import (
+	"bytes"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/iamolegga/valmid"
+)
+
+type StreamInput struct {
+	Token  string        `in:"header=X-Token" validate:"required"`
+	Upload io.ReadCloser `in:"body=stream"`
+}
+
+type MultipartInput struct {
+	Token string            `in:"header=X-Token" validate:"required"`
+	Parts *multipart.Reader `in:"body=multipart"`
+}
+
+type MultipartInputWithScalarDirectives struct {
+	Page  int               `in:"query=page;default=1"`
+	Token string            `in:"header=X-Token;required"`
+	Parts *multipart.Reader `in:"body=multipart"`
+}
+
+func TestMiddleware_BodyStream(t *testing.T) {
+	handler := valmid.Middleware[StreamInput]()(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		input := valmid.Get[StreamInput](r)
+		defer input.Upload.Close()
+
+		got, err := io.ReadAll(input.Upload)
+		if err != nil {
+			t.Fatalf("failed to read streamed upload: %v", err)
+		}
+		if string(got) != "a very large upload" {
+			t.Errorf("unexpected upload content: %q", got)
+		}
+	}))
+
+	req := httptest.NewRequest("POST", "/", bytes.NewBufferString("a very large upload"))
+	req.Header.Set("X-Token", "secret")
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusOK {
+		t.Errorf("expected 200, got %d: %s", rec.Code, rec.Body.String())
+	}
+}
+
+func TestMiddleware_BodyStream_SiblingValidationStillApplies(t *testing.T) {
+	handler := valmid.Middleware[StreamInput]()(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		t.Fatal("should not be called")
+	}))
+
+	req := httptest.NewRequest("POST", "/", bytes.NewBufferString("upload"))
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusUnprocessableEntity {
+		t.Errorf("expected 422 for missing X-Token, got %d: %s", rec.Code, rec.Body.String())
+	}
+}
+
+func TestMiddleware_BodyMultipart(t *testing.T) {
+	var body bytes.Buffer
+	mw := multipart.NewWriter(&body)
+	part, err := mw.CreateFormFile("file", "upload.txt")
+	if err != nil {
+		t.Fatalf("failed to create form file: %v", err)
+	}
+	if _, err := part.Write([]byte("streamed part content")); err != nil {
+		t.Fatalf("failed to write form file: %v", err)
+	}
+	if err := mw.Close(); err != nil {
+		t.Fatalf("failed to close multipart writer: %v", err)
+	}
+
+	handler := valmid.Middleware[MultipartInput]()(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		input := valmid.Get[MultipartInput](r)
+
+		part, err := input.Parts.NextPart()
+		if err != nil {
+			t.Fatalf("failed to read first part: %v", err)
+		}
+		defer part.Close()
+
+		got, err := io.ReadAll(part)
+		if err != nil {
+			t.Fatalf("failed to read part content: %v", err)
+		}
+		if string(got) != "streamed part content" {
+			t.Errorf("unexpected part content: %q", got)
+		}
+	}))
+
+	req := httptest.NewRequest("POST", "/", &body)
+	req.Header.Set("Content-Type", mw.FormDataContentType())
+	req.Header.Set("X-Token", "secret")
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusOK {
+		t.Errorf("expected 200, got %d: %s", rec.Code, rec.Body.String())
+	}
+}
+
+func TestMiddleware_BodyMultipart_ScalarDirectivesHonored(t *testing.T) {
+	handler := valmid.Middleware[MultipartInputWithScalarDirectives]()(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		t.Fatal("should not be called")
+	}))
+
+	var body bytes.Buffer
+	mw := multipart.NewWriter(&body)
+	if err := mw.Close(); err != nil {
+		t.Fatalf("failed to close multipart writer: %v", err)
+	}
+
+	req := httptest.NewRequest("POST", "/", &body)
+	req.Header.Set("Content-Type", mw.FormDataContentType())
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+
+	if rec.Code == http.StatusOK {
+		t.Fatalf("expected a non-2xx response for missing required X-Token, got 200")
+	}
+}
+
+func TestMiddleware_BodyMultipart_ScalarDefaultApplied(t *testing.T) {
+	var gotPage int
+	handler := valmid.Middleware[MultipartInputWithScalarDirectives]()(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotPage = valmid.Get[MultipartInputWithScalarDirectives](r).Page
+	}))
+
+	var body bytes.Buffer
+	mw := multipart.NewWriter(&body)
+	if err := mw.Close(); err != nil {
+		t.Fatalf("failed to close multipart writer: %v", err)
+	}
+
+	req := httptest.NewRequest("POST", "/", &body)
+	req.Header.Set("Content-Type", mw.FormDataContentType())
+	req.Header.Set("X-Token", "secret")
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", rec.Code, rec.Body.String())
+	}
+	if gotPage != 1 {
+		t.Errorf("expected default Page=1, got %d", gotPage)
+	}
+}
@@ -0,0 +1,45 @@
+package valmid
+
+import (
+	"fmt"
+
+	ut "github.com/go-playground/universal-translator"
+	"github.com/go-playground/validator/v10"
+	"github.com/go-playground/validator/v10/translations/de"
+	"github.com/go-playground/validator/v10/translations/en"
+	"github.com/go-playground/validator/v10/translations/es"
+	"github.com/go-playground/validator/v10/translations/fr"
+)
+
+// registerTranslations registers locale's upstream messages against uni's
+// Translator for that locale and the global validator (see SetValidator).
+func registerTranslations(locale string, register func(*validator.Validate, ut.Translator) error, uni *ut.UniversalTranslator) error {
+	trans, found := uni.GetTranslator(locale)
+	if !found {
+		return fmt.Errorf("valmid: uni has no %q translator registered", locale)
+	}
+	return register(getValidator(), trans)
+}
+
+// RegisterEnglishTranslations registers go-playground/validator's built-in
+// English messages against uni's "en" Translator and the global validator,
+// so validation errors translate once SetTranslator(uni) and a matching
+// locale (see WithLocale) are wired up.
+func RegisterEnglishTranslations(uni *ut.UniversalTranslator) error {
+	return registerTranslations("en", en.RegisterDefaultTranslations, uni)
+}
+
+// RegisterSpanishTranslations does the same for Spanish ("es").
+func RegisterSpanishTranslations(uni *ut.UniversalTranslator) error {
+	return registerTranslations("es", es.RegisterDefaultTranslations, uni)
+}
+
+// RegisterFrenchTranslations does the same for French ("fr").
+func RegisterFrenchTranslations(uni *ut.UniversalTranslator) error {
+	return registerTranslations("fr", fr.RegisterDefaultTranslations, uni)
+}
+
+// RegisterGermanTranslations does the same for German ("de").
+func RegisterGermanTranslations(uni *ut.UniversalTranslator) error {
+	return registerTranslations("de", de.RegisterDefaultTranslations, uni)
+}
@@ -2,7 +2,9 @@ package valmid
 
 import (
 	"context"
+	"errors"
 	"net/http"
+	"reflect"
 	"sync"
 
 	"github.com/ggicci/httpin"
@@ -19,11 +21,9 @@ func init() {
 type ErrorHandlerFunc func(w http.ResponseWriter, r *http.Request, err error)
 
 var (
-	defaultErrorHandler ErrorHandlerFunc = func(w http.ResponseWriter, r *http.Request, err error) {
-		http.Error(w, err.Error(), http.StatusBadRequest)
-	}
-	defaultValidator = validator.New()
-	mu               sync.RWMutex
+	defaultErrorHandler ErrorHandlerFunc = ProblemDetailsHandler
+	defaultValidator                     = validator.New()
+	mu                  sync.RWMutex
 )
 
 // SetErrorHandler sets the default error handler for all middlewares.
@@ -57,6 +57,7 @@ func getValidator() *validator.Validate {
 // options holds per-middleware configuration.
 type options struct {
 	errorHandler ErrorHandlerFunc
+	locale       LocaleFunc
 }
 
 // Option configures the middleware.
@@ -80,24 +81,51 @@ func Middleware[T any](opts ...Option) func(http.Handler) http.Handler {
 	}
 
 	var t T
-	core, err := httpin.New(t)
-	if err != nil {
-		panic("valmid: failed to create httpin core: " + err.Error())
+	tType := reflect.TypeOf(t)
+	streamFields := streamingBodyFields(tType)
+
+	decode := newHttpinDecoder[T](t)
+	if idx, ok := multipartBodyField(tType); ok {
+		// httpin.Core.Decode always buffers multipart/form-data bodies (see
+		// autoBodyDirective in body.go), so a body=multipart field has to
+		// bypass httpin entirely.
+		binder, err := newMultipartBinder(tType, idx)
+		if err != nil {
+			panic("valmid: " + err.Error())
+		}
+		decode = binder.decode
 	}
 
 	return func(next http.Handler) http.Handler {
 		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-			// Decode request using httpin
-			input, err := core.Decode(r)
+			input, err := decode(r)
 			if err != nil {
-				handleError(w, r, o, err)
+				var mediaTypeErr *UnsupportedMediaTypeError
+				if errors.As(err, &mediaTypeErr) {
+					http.Error(w, mediaTypeErr.Error(), http.StatusUnsupportedMediaType)
+					return
+				}
+				handleError(w, withRequestLocale(r, o), o, err)
 				return
 			}
 
-			// Validate using go-playground/validator
+			// Validate using go-playground/validator, skipping any
+			// in:"body=stream" or in:"body=multipart" field so a handler can
+			// still consume a multi-GB body afterwards.
 			v := getValidator()
-			if err := v.Struct(input); err != nil {
-				handleError(w, r, o, err)
+			var verr error
+			if len(streamFields) > 0 {
+				verr = v.StructExcept(input, streamFields...)
+			} else {
+				verr = v.Struct(input)
+			}
+			if verr != nil {
+				req := withRequestLocale(r, o)
+				var ve validator.ValidationErrors
+				if errors.As(verr, &ve) {
+					verr = newValidationErrors(req, ve)
+				}
+				handleError(w, req, o, verr)
 				return
 			}
 
@@ -108,6 +136,16 @@ func Middleware[T any](opts ...Option) func(http.Handler) http.Handler {
 	}
 }
 
+// newHttpinDecoder builds the default decode function for T: a thin wrapper
+// around an httpin Core's Decode.
+func newHttpinDecoder[T any](zero T) func(*http.Request) (any, error) {
+	core, err := httpin.New(zero)
+	if err != nil {
+		panic("valmid: failed to create httpin core: " + err.Error())
+	}
+	return core.Decode
+}
+
 func handleError(w http.ResponseWriter, r *http.Request, o *options, err error) {
 	handler := o.errorHandler
 	if handler == nil {
@@ -15,11 +15,11 @@ type Body struct {
 }
 
 type Input struct {
-	ID     int    `in:"path=id" validate:"gt=0"`
-	Page   int    `in:"query=page;default=1"`
-	Token  string `in:"header=X-Token" validate:"required"`
-	Body   *Body  `in:"body=json"`
-	Field  string `in:"form=field"`
+	ID    int    `in:"path=id" validate:"gt=0"`
+	Page  int    `in:"query=page;default=1"`
+	Token string `in:"header=X-Token" validate:"required"`
+	Body  *Body  `in:"body=json"`
+	Field string `in:"form=field"`
 }
 
 type BadInput struct {
@@ -70,8 +70,8 @@ func TestMiddleware_ValidationError(t *testing.T) {
 	rec := httptest.NewRecorder()
 	handler.ServeHTTP(rec, req)
 
-	if rec.Code != http.StatusBadRequest {
-		t.Errorf("expected 400, got %d", rec.Code)
+	if rec.Code != http.StatusUnprocessableEntity {
+		t.Errorf("expected 422, got %d", rec.Code)
 	}
 }
 